@@ -0,0 +1,47 @@
+package main
+
+import (
+	"go/types"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// lockEmbed describes a sync.Mutex/sync.RWMutex embedded in a struct, used by
+// concurrent generation (Options.Concurrent) to decide which locking calls to emit.
+type lockEmbed struct {
+	FieldName string // promoted field name accessor code locks on, e.g. "RWMutex"
+	RWCapable bool   // true if the embed is sync.RWMutex (supports RLock/RUnlock)
+}
+
+// findLockEmbed looks for a sync.Mutex or sync.RWMutex embedded anonymously in the
+// struct named structName across pkgs, returning ok=false if none is found.
+func findLockEmbed(pkgs []*packages.Package, structName string) (embed lockEmbed, ok bool) {
+	for _, pkg := range pkgs {
+		obj := pkg.Types.Scope().Lookup(structName)
+		if obj == nil {
+			continue
+		}
+		structType, isStruct := obj.Type().Underlying().(*types.Struct)
+		if !isStruct {
+			continue
+		}
+
+		for i := 0; i < structType.NumFields(); i++ {
+			field := structType.Field(i)
+			if !field.Embedded() {
+				continue
+			}
+			named, isNamed := field.Type().(*types.Named)
+			if !isNamed || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "sync" {
+				continue
+			}
+			switch named.Obj().Name() {
+			case "RWMutex":
+				return lockEmbed{FieldName: field.Name(), RWCapable: true}, true
+			case "Mutex":
+				return lockEmbed{FieldName: field.Name(), RWCapable: false}, true
+			}
+		}
+	}
+	return lockEmbed{}, false
+}
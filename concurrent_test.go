@@ -0,0 +1,89 @@
+package main
+
+import (
+	"go/types"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestFindLockEmbed(t *testing.T) {
+	syncPkg := types.NewPackage("sync", "sync")
+	mutexNamed := types.NewNamed(types.NewTypeName(0, syncPkg, "Mutex", nil), types.NewStruct(nil, nil), nil)
+	rwMutexNamed := types.NewNamed(types.NewTypeName(0, syncPkg, "RWMutex", nil), types.NewStruct(nil, nil), nil)
+
+	newStructPkg := func(structName string, fields []*types.Var) []*packages.Package {
+		pkg := types.NewPackage("example.com/test", "test")
+		structType := types.NewStruct(fields, nil)
+		named := types.NewNamed(types.NewTypeName(0, pkg, structName, nil), structType, nil)
+		pkg.Scope().Insert(named.Obj())
+		return []*packages.Package{{Types: pkg}}
+	}
+
+	tests := []struct {
+		name          string
+		pkgs          []*packages.Package
+		structName    string
+		wantFound     bool
+		wantFieldName string
+		wantRWCapable bool
+	}{
+		{
+			name: "embedded sync.Mutex",
+			pkgs: newStructPkg("Counter", []*types.Var{
+				types.NewField(0, nil, "Mutex", mutexNamed, true),
+			}),
+			structName:    "Counter",
+			wantFound:     true,
+			wantFieldName: "Mutex",
+			wantRWCapable: false,
+		},
+		{
+			name: "embedded sync.RWMutex",
+			pkgs: newStructPkg("Counter", []*types.Var{
+				types.NewField(0, nil, "RWMutex", rwMutexNamed, true),
+			}),
+			structName:    "Counter",
+			wantFound:     true,
+			wantFieldName: "RWMutex",
+			wantRWCapable: true,
+		},
+		{
+			name: "non-embedded mutex field does not count",
+			pkgs: newStructPkg("Counter", []*types.Var{
+				types.NewField(0, nil, "M", mutexNamed, false),
+			}),
+			structName: "Counter",
+			wantFound:  false,
+		},
+		{
+			name: "no lock embed",
+			pkgs: newStructPkg("Counter", []*types.Var{
+				types.NewField(0, nil, "Value", types.Typ[types.Int], false),
+			}),
+			structName: "Counter",
+			wantFound:  false,
+		},
+		{
+			name:       "unknown struct",
+			pkgs:       newStructPkg("Counter", nil),
+			structName: "Nope",
+			wantFound:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := findLockEmbed(tt.pkgs, tt.structName)
+			if ok != tt.wantFound {
+				t.Fatalf("findLockEmbed() ok = %v, want %v", ok, tt.wantFound)
+			}
+			if !ok {
+				return
+			}
+			if got.FieldName != tt.wantFieldName || got.RWCapable != tt.wantRWCapable {
+				t.Fatalf("findLockEmbed() = %+v, want {FieldName: %q, RWCapable: %v}", got, tt.wantFieldName, tt.wantRWCapable)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package main
+
+import (
+	"fmt"
+	"go/types"
+	"os"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultConfigFile is the config file name used when Options.ConfigPath is not set.
+const defaultConfigFile = ".accessorgen.yaml"
+
+// StructOverride customizes generation for a single struct, keyed by struct name in
+// Config.Structs.
+type StructOverride struct {
+	Mode   string            `yaml:"mode"`   // "getter", "setter", or "accessor"; empty means use the file-level mode
+	Skip   []string          `yaml:"skip"`   // field names to omit from generation entirely
+	Rename map[string]string `yaml:"rename"` // field name -> generated method name
+}
+
+// TypeOverride supplies explicit getter/setter method bodies for a custom Go type,
+// e.g. wrapping sql.NullString with dereference logic. Getter/Setter are Go source
+// snippets substituted verbatim into the generated method body.
+type TypeOverride struct {
+	Getter string `yaml:"getter"`
+	Setter string `yaml:"setter"`
+}
+
+// Config is the shape of the YAML config file (default ".accessorgen.yaml") that
+// drives config-based generation, as an alternative to flag-based Options.
+type Config struct {
+	Packages []string                  `yaml:"packages"` // globs of source paths to process
+	Structs  map[string]StructOverride `yaml:"structs"`
+	Types    map[string]TypeOverride   `yaml:"types"`
+	Output   string                    `yaml:"output"` // output filename template, e.g. "{{.Base}}_accessor_gen.go"
+}
+
+// LoadConfig reads and parses the YAML config file at path. A missing file is not an
+// error; callers should fall back to flag-based Options in that case.
+func LoadConfig(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("error reading config file %s: %w", path, err)
+	}
+
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("error parsing config file %s: %w", path, err)
+	}
+
+	return &cfg, nil
+}
+
+// Validate checks that every struct, field, and type name referenced in c.Structs and
+// c.Types actually exists in pkgs, returning a descriptive error on the first mismatch
+// found. Callers that split generation across multiple directories should pass the
+// union of packages across all of them, since a single config may reference structs
+// and types scattered across a monorepo.
+func (c *Config) Validate(pkgs []*packages.Package) error {
+	for structName, override := range c.Structs {
+		obj := lookupStruct(pkgs, structName)
+		if obj == nil {
+			return fmt.Errorf("config references unknown struct %q", structName)
+		}
+
+		for _, fieldName := range override.Skip {
+			if !structHasField(obj, fieldName) {
+				return fmt.Errorf("config references unknown field %q on struct %q", fieldName, structName)
+			}
+		}
+		for fieldName := range override.Rename {
+			if !structHasField(obj, fieldName) {
+				return fmt.Errorf("config references unknown field %q on struct %q", fieldName, structName)
+			}
+		}
+	}
+
+	for typeName := range c.Types {
+		if !lookupType(pkgs, typeName) {
+			return fmt.Errorf("config references unknown type %q", typeName)
+		}
+	}
+
+	return nil
+}
+
+// lookupStruct finds the *types.Struct underlying structName among pkgs, or nil if
+// no such struct is defined.
+func lookupStruct(pkgs []*packages.Package, structName string) *types.Struct {
+	for _, pkg := range pkgs {
+		obj := pkg.Types.Scope().Lookup(structName)
+		if obj == nil {
+			continue
+		}
+		if structType, ok := obj.Type().Underlying().(*types.Struct); ok {
+			return structType
+		}
+	}
+	return nil
+}
+
+// lookupType reports whether typeName is a real type among pkgs. typeName may be
+// unqualified (e.g. "MyType", looked up in pkgs directly) or package-qualified (e.g.
+// "sql.NullString", resolved against one of pkgs' imports by package name).
+func lookupType(pkgs []*packages.Package, typeName string) bool {
+	pkgAlias, name, qualified := "", typeName, false
+	if idx := strings.LastIndex(typeName, "."); idx >= 0 {
+		pkgAlias, name, qualified = typeName[:idx], typeName[idx+1:], true
+	}
+
+	for _, pkg := range pkgs {
+		if !qualified {
+			if obj := pkg.Types.Scope().Lookup(name); obj != nil {
+				if _, ok := obj.(*types.TypeName); ok {
+					return true
+				}
+			}
+			continue
+		}
+
+		for _, imp := range pkg.Imports {
+			if imp.Types == nil || imp.Types.Name() != pkgAlias {
+				continue
+			}
+			if obj := imp.Types.Scope().Lookup(name); obj != nil {
+				if _, ok := obj.(*types.TypeName); ok {
+					return true
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// structHasField reports whether structType declares a field named fieldName.
+func structHasField(structType *types.Struct, fieldName string) bool {
+	for i := 0; i < structType.NumFields(); i++ {
+		if structType.Field(i).Name() == fieldName {
+			return true
+		}
+	}
+	return false
+}
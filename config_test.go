@@ -0,0 +1,195 @@
+package main
+
+import (
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+func TestLoadConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		missing bool
+		want    *Config
+	}{
+		{
+			name: "full config",
+			yaml: `
+packages:
+  - "./..."
+structs:
+  Foo:
+    mode: getter
+    skip: [Bar]
+    rename:
+      Baz: GetBaz
+types:
+  sql.NullString:
+    getter: "return s.String"
+    setter: "s.String = v"
+output: "{{.Base}}_accessor_gen.go"
+`,
+			want: &Config{
+				Packages: []string{"./..."},
+				Structs: map[string]StructOverride{
+					"Foo": {Mode: "getter", Skip: []string{"Bar"}, Rename: map[string]string{"Baz": "GetBaz"}},
+				},
+				Types: map[string]TypeOverride{
+					"sql.NullString": {Getter: "return s.String", Setter: "s.String = v"},
+				},
+				Output: "{{.Base}}_accessor_gen.go",
+			},
+		},
+		{
+			name:    "missing file is not an error",
+			missing: true,
+			want:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			path := filepath.Join(dir, ".accessorgen.yaml")
+			if !tt.missing {
+				if err := os.WriteFile(path, []byte(tt.yaml), 0o644); err != nil {
+					t.Fatalf("WriteFile: %v", err)
+				}
+			}
+
+			got, err := LoadConfig(path)
+			if err != nil {
+				t.Fatalf("LoadConfig() error = %v", err)
+			}
+			if !configsEqual(got, tt.want) {
+				t.Fatalf("LoadConfig() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	pkgs := []*packages.Package{newTestPackage()}
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr bool
+	}{
+		{
+			name: "valid struct and field references",
+			cfg: Config{
+				Structs: map[string]StructOverride{
+					"Foo": {Skip: []string{"Bar"}, Rename: map[string]string{"Baz": "GetBaz"}},
+				},
+			},
+		},
+		{
+			name: "unknown struct",
+			cfg: Config{
+				Structs: map[string]StructOverride{"Nope": {}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown field in skip",
+			cfg: Config{
+				Structs: map[string]StructOverride{"Foo": {Skip: []string{"Nope"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unknown field in rename",
+			cfg: Config{
+				Structs: map[string]StructOverride{"Foo": {Rename: map[string]string{"Nope": "X"}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid unqualified type reference",
+			cfg: Config{
+				Types: map[string]TypeOverride{"MyType": {}},
+			},
+		},
+		{
+			name: "valid qualified type reference",
+			cfg: Config{
+				Types: map[string]TypeOverride{"sql.NullString": {}},
+			},
+		},
+		{
+			name: "unknown type",
+			cfg: Config{
+				Types: map[string]TypeOverride{"sql.NoSuchType": {}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate(pkgs)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+// newTestPackage builds a *packages.Package with a struct Foo{Bar string; Baz int}, an
+// unqualified named type MyType, and an import of a fake "sql" package defining
+// NullString, for exercising Config.Validate without a real go/packages.Load.
+func newTestPackage() *packages.Package {
+	pkg := types.NewPackage("example.com/test", "test")
+
+	structType := types.NewStruct([]*types.Var{
+		types.NewField(0, pkg, "Bar", types.Typ[types.String], false),
+		types.NewField(0, pkg, "Baz", types.Typ[types.Int], false),
+	}, nil)
+	fooNamed := types.NewNamed(types.NewTypeName(0, pkg, "Foo", nil), structType, nil)
+	pkg.Scope().Insert(fooNamed.Obj())
+
+	myType := types.NewNamed(types.NewTypeName(0, pkg, "MyType", nil), types.Typ[types.String], nil)
+	pkg.Scope().Insert(myType.Obj())
+
+	sqlPkg := types.NewPackage("database/sql", "sql")
+	nullString := types.NewNamed(types.NewTypeName(0, sqlPkg, "NullString", nil), types.NewStruct(nil, nil), nil)
+	sqlPkg.Scope().Insert(nullString.Obj())
+
+	return &packages.Package{
+		Types:   pkg,
+		Imports: map[string]*packages.Package{"database/sql": {Types: sqlPkg}},
+	}
+}
+
+func configsEqual(a, b *Config) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	if len(a.Packages) != len(b.Packages) || len(a.Structs) != len(b.Structs) ||
+		len(a.Types) != len(b.Types) || a.Output != b.Output {
+		return false
+	}
+	for i, p := range a.Packages {
+		if b.Packages[i] != p {
+			return false
+		}
+	}
+	for name, override := range a.Structs {
+		other, ok := b.Structs[name]
+		if !ok || override.Mode != other.Mode || len(override.Skip) != len(other.Skip) || len(override.Rename) != len(other.Rename) {
+			return false
+		}
+	}
+	for name, override := range a.Types {
+		other, ok := b.Types[name]
+		if !ok || override != other {
+			return false
+		}
+	}
+	return true
+}
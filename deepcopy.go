@@ -0,0 +1,212 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+)
+
+// collectStructNames gathers the names of all struct types declared in node, used by
+// deep-copy generation to decide between a plain field assignment and a recursive
+// DeepCopyInto call on a sibling generated type.
+func collectStructNames(node *ast.File) map[string]bool {
+	names := make(map[string]bool)
+	for _, decl := range node.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec, ok := spec.(*ast.TypeSpec)
+			if !ok {
+				continue
+			}
+			if _, ok := typeSpec.Type.(*ast.StructType); ok {
+				names[typeSpec.Name.Name] = true
+			}
+		}
+	}
+	return names
+}
+
+// buildDeepCopyStmt returns the Go statement(s) methodTemplate should emit inside
+// DeepCopyInto to copy fieldName from in to out in ModeDeepCopy, or "" when the field is
+// left to the blanket `*out = *in` shallow copy generated at the top of DeepCopyInto.
+// That copy is complete for primitive value fields, fixed-size arrays of plain values,
+// and opaque external struct types. The choice is made from the field's syntax alone:
+// a field whose type is a package-qualified type, or an identifier other than "any" or
+// a struct declared in the same file (e.g. a named slice, map, pointer or interface
+// type such as error), also gets "", so out shares that field's memory with in.
+func buildDeepCopyStmt(fieldName string, fieldExpr ast.Expr, fieldTypeStr string, knownStructs map[string]bool, shallowInterface bool) string {
+	switch t := fieldExpr.(type) {
+	case *ast.StarExpr:
+		elem := exprToString(t.X)
+		if knownStructs[elem] {
+			return fmt.Sprintf(
+				"if in.%s != nil {\n\tout.%s = new(%s)\n\tin.%s.DeepCopyInto(out.%s)\n}",
+				fieldName, fieldName, elem, fieldName, fieldName,
+			)
+		}
+		return fmt.Sprintf(
+			"if in.%s != nil {\n\tout.%s = new(%s)\n\t*out.%s = *in.%s\n}",
+			fieldName, fieldName, elem, fieldName, fieldName,
+		)
+	case *ast.MapType:
+		return containerDeepCopyStmt(
+			fmt.Sprintf("out.%s", fieldName), fmt.Sprintf("in.%s", fieldName),
+			t, fieldTypeStr, knownStructs, 1,
+		)
+	case *ast.ArrayType:
+		if t.Len != nil {
+			if !elemNeedsDeepCopy(t.Elt, knownStructs) {
+				// Fixed-size array of plain values: the blanket `*out = *in` copy already suffices.
+				return ""
+			}
+			// Elements that alias memory are deep-copied in place after the blanket copy.
+			idx := loopVar(1)
+			return fmt.Sprintf(
+				"for %s := range in.%s {\n\t%s\n}",
+				idx, fieldName,
+				elemDeepCopyStmt(fmt.Sprintf("out.%s[%s]", fieldName, idx), fmt.Sprintf("in.%s[%s]", fieldName, idx), t.Elt, knownStructs, 2),
+			)
+		}
+		return containerDeepCopyStmt(
+			fmt.Sprintf("out.%s", fieldName), fmt.Sprintf("in.%s", fieldName),
+			t, fieldTypeStr, knownStructs, 1,
+		)
+	case *ast.InterfaceType:
+		if shallowInterface {
+			return fmt.Sprintf("out.%s = in.%s // shallow copy: accessor:\"deepcopy=shallow\"", fieldName, fieldName)
+		}
+		return fmt.Sprintf("// TODO: %s is an interface field; deep copy not generated (opt in with accessor:\"deepcopy=shallow\")", fieldName)
+	case *ast.Ident:
+		if t.Name == "any" {
+			if shallowInterface {
+				return fmt.Sprintf("out.%s = in.%s // shallow copy: accessor:\"deepcopy=shallow\"", fieldName, fieldName)
+			}
+			return fmt.Sprintf("// TODO: %s is an interface field; deep copy not generated (opt in with accessor:\"deepcopy=shallow\")", fieldName)
+		}
+		if knownStructs[t.Name] {
+			return fmt.Sprintf("in.%s.DeepCopyInto(&out.%s)", fieldName, fieldName)
+		}
+		return ""
+	default:
+		return ""
+	}
+}
+
+// elemNeedsDeepCopy reports whether a slice/map element of type elemExpr aliases
+// memory that a flat copy/element-assign would share between in and out: pointers,
+// values of a sibling generated struct type, and nested slices/maps (which are
+// themselves reference types, so even a []int inside a [][]int needs its own
+// make+copy to avoid sharing the inner backing array).
+func elemNeedsDeepCopy(elemExpr ast.Expr, knownStructs map[string]bool) bool {
+	switch t := elemExpr.(type) {
+	case *ast.StarExpr:
+		return true
+	case *ast.Ident:
+		return knownStructs[t.Name]
+	case *ast.ArrayType:
+		return t.Len == nil
+	case *ast.MapType:
+		return true
+	default:
+		return false
+	}
+}
+
+// containerDeepCopyStmt returns the Go statement that deep-copies the slice or map
+// typeExpr from src into dst, recursing into element/value types that themselves need
+// a deep copy (pointers, sibling structs, and nested slices/maps) at any depth. dst and
+// src are expressions referring to the container itself (e.g. "out.Field"/"in.Field",
+// or "out.Field[i]"/"in.Field[i]" one level down); depth disambiguates the loop and
+// temporary variable names introduced at each nesting level.
+func containerDeepCopyStmt(dst, src string, typeExpr ast.Expr, typeStr string, knownStructs map[string]bool, depth int) string {
+	switch t := typeExpr.(type) {
+	case *ast.MapType:
+		if !elemNeedsDeepCopy(t.Value, knownStructs) {
+			return fmt.Sprintf(
+				"if %s != nil {\n\t%s = make(%s, len(%s))\n\tfor k, v := range %s {\n\t\t%s[k] = v\n\t}\n}",
+				src, dst, typeStr, src, src, dst,
+			)
+		}
+		// Map values aren't addressable, so each one is deep-copied into a local
+		// before being stored back, rather than copied in place like a slice element.
+		tmp := tmpVar(depth)
+		return fmt.Sprintf(
+			"if %s != nil {\n\t%s = make(%s, len(%s))\n\tfor k, v := range %s {\n\t\tvar %s %s\n\t\t%s\n\t\t%s[k] = %s\n\t}\n}",
+			src, dst, typeStr, src, src, tmp, exprToString(t.Value),
+			elemDeepCopyStmt(tmp, "v", t.Value, knownStructs, depth+1), dst, tmp,
+		)
+	case *ast.ArrayType:
+		if !elemNeedsDeepCopy(t.Elt, knownStructs) {
+			return fmt.Sprintf(
+				"if %s != nil {\n\t%s = make(%s, len(%s))\n\tcopy(%s, %s)\n}",
+				src, dst, typeStr, src, dst, src,
+			)
+		}
+		// Slice elements are addressable, so each one is deep-copied directly between
+		// the corresponding out/in index instead of through a local, unlike a map value.
+		idx := loopVar(depth)
+		elemDst := fmt.Sprintf("%s[%s]", dst, idx)
+		elemSrc := fmt.Sprintf("%s[%s]", src, idx)
+		return fmt.Sprintf(
+			"if %s != nil {\n\t%s = make(%s, len(%s))\n\tfor %s := range %s {\n\t\t%s\n\t}\n}",
+			src, dst, typeStr, src, idx, src,
+			elemDeepCopyStmt(elemDst, elemSrc, t.Elt, knownStructs, depth+1),
+		)
+	default:
+		return fmt.Sprintf("%s = %s", dst, src)
+	}
+}
+
+// elemDeepCopyStmt returns the Go statement that deep-copies one slice/map element of
+// type elemExpr from src into dst. dst and src are expressions referring to the
+// destination and source elements (e.g. "out.Field[i]"/"in.Field[i]", or local variable
+// names for a map value that isn't addressable); nested slice/map element types
+// recurse through containerDeepCopyStmt.
+func elemDeepCopyStmt(dst, src string, elemExpr ast.Expr, knownStructs map[string]bool, depth int) string {
+	switch t := elemExpr.(type) {
+	case *ast.StarExpr:
+		elem := exprToString(t.X)
+		if knownStructs[elem] {
+			return fmt.Sprintf(
+				"if %s != nil {\n\t\t%s = new(%s)\n\t\t%s.DeepCopyInto(%s)\n\t}",
+				src, dst, elem, src, dst,
+			)
+		}
+		return fmt.Sprintf(
+			"if %s != nil {\n\t\t%s = new(%s)\n\t\t*%s = *%s\n\t}",
+			src, dst, elem, dst, src,
+		)
+	case *ast.Ident:
+		if knownStructs[t.Name] {
+			return fmt.Sprintf("%s.DeepCopyInto(&%s)", src, dst)
+		}
+		return fmt.Sprintf("%s = %s", dst, src)
+	case *ast.ArrayType, *ast.MapType:
+		return containerDeepCopyStmt(dst, src, elemExpr, exprToString(elemExpr), knownStructs, depth)
+	default:
+		return fmt.Sprintf("%s = %s", dst, src)
+	}
+}
+
+// loopVar returns the range-loop index variable name used at a given slice nesting
+// depth (1-based), so a nested slice-of-slices doesn't shadow its own outer index.
+func loopVar(depth int) string {
+	vars := []string{"i", "j", "k", "l", "m", "n"}
+	if depth >= 1 && depth <= len(vars) {
+		return vars[depth-1]
+	}
+	return fmt.Sprintf("idx%d", depth)
+}
+
+// tmpVar returns the local variable name used to hold a deep-copied map value at a
+// given map nesting depth (1-based), so a nested map-of-maps doesn't shadow its own
+// outer temporary.
+func tmpVar(depth int) string {
+	if depth == 1 {
+		return "copied"
+	}
+	return fmt.Sprintf("copied%d", depth)
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"go/ast"
+	"strings"
+	"testing"
+)
+
+func TestBuildDeepCopyStmt(t *testing.T) {
+	knownStructs := map[string]bool{"Inner": true}
+
+	ptrTo := func(name string) ast.Expr {
+		return &ast.StarExpr{X: ast.NewIdent(name)}
+	}
+	sliceOf := func(elt ast.Expr) ast.Expr {
+		return &ast.ArrayType{Elt: elt}
+	}
+	mapOf := func(val ast.Expr) ast.Expr {
+		return &ast.MapType{Key: ast.NewIdent("string"), Value: val}
+	}
+
+	tests := []struct {
+		name         string
+		fieldExpr    ast.Expr
+		fieldTypeStr string
+		wantContains []string
+		wantEmpty    bool
+	}{
+		{
+			name:         "slice of plain ints uses a flat copy",
+			fieldExpr:    sliceOf(ast.NewIdent("int")),
+			fieldTypeStr: "[]int",
+			wantContains: []string{"copy(out.F, in.F)"},
+		},
+		{
+			name:         "slice of pointers recurses per element",
+			fieldExpr:    sliceOf(ptrTo("int")),
+			fieldTypeStr: "[]*int",
+			wantContains: []string{"for i := range in.F", "new(int)", "*out.F[i] = *in.F[i]"},
+		},
+		{
+			name:         "slice of pointers to known structs calls DeepCopyInto",
+			fieldExpr:    sliceOf(ptrTo("Inner")),
+			fieldTypeStr: "[]*Inner",
+			wantContains: []string{"for i := range in.F", "new(Inner)", "in.F[i].DeepCopyInto(out.F[i])"},
+		},
+		{
+			name:         "slice of known structs calls DeepCopyInto",
+			fieldExpr:    sliceOf(ast.NewIdent("Inner")),
+			fieldTypeStr: "[]Inner",
+			wantContains: []string{"for i := range in.F", "in.F[i].DeepCopyInto(&out.F[i])"},
+		},
+		{
+			name:         "map of plain values uses a flat assign",
+			fieldExpr:    mapOf(ast.NewIdent("int")),
+			fieldTypeStr: "map[string]int",
+			wantContains: []string{"out.F[k] = v"},
+		},
+		{
+			name:         "map of pointers recurses through a local",
+			fieldExpr:    mapOf(ptrTo("int")),
+			fieldTypeStr: "map[string]*int",
+			wantContains: []string{"var copied *int", "new(int)", "*copied = *v", "out.F[k] = copied"},
+		},
+		{
+			name:         "map of known structs recurses through a local",
+			fieldExpr:    mapOf(ast.NewIdent("Inner")),
+			fieldTypeStr: "map[string]Inner",
+			wantContains: []string{"var copied Inner", "v.DeepCopyInto(&copied)", "out.F[k] = copied"},
+		},
+		{
+			name:      "fixed-size array is handled by the blanket copy",
+			fieldExpr: &ast.ArrayType{Len: &ast.BasicLit{Value: "4"}, Elt: ast.NewIdent("int")},
+			wantEmpty: true,
+		},
+		{
+			name:         "slice of slices recurses per row instead of sharing backing arrays",
+			fieldExpr:    sliceOf(sliceOf(ast.NewIdent("int"))),
+			fieldTypeStr: "[][]int",
+			wantContains: []string{"for i := range in.F", "make([]int, len(in.F[i]))", "copy(out.F[i], in.F[i])"},
+		},
+		{
+			name:         "map of slices recurses through a local instead of sharing backing arrays",
+			fieldExpr:    mapOf(sliceOf(ast.NewIdent("int"))),
+			fieldTypeStr: "map[string][]int",
+			wantContains: []string{"var copied []int", "make([]int, len(v))", "copy(copied, v)", "out.F[k] = copied"},
+		},
+		{
+			name:         "slice of maps recurses per element instead of sharing the map",
+			fieldExpr:    sliceOf(mapOf(ast.NewIdent("int"))),
+			fieldTypeStr: "[]map[string]int",
+			wantContains: []string{"for i := range in.F", "make(map[string]int, len(in.F[i]))", "out.F[i][k] = v"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := buildDeepCopyStmt("F", tt.fieldExpr, tt.fieldTypeStr, knownStructs, false)
+			if tt.wantEmpty {
+				if got != "" {
+					t.Fatalf("buildDeepCopyStmt() = %q, want empty", got)
+				}
+				return
+			}
+			for _, want := range tt.wantContains {
+				if !strings.Contains(got, want) {
+					t.Fatalf("buildDeepCopyStmt() = %q, want it to contain %q", got, want)
+				}
+			}
+		})
+	}
+}
@@ -17,7 +17,10 @@ type loadPackagesResponse struct {
 	astFiles *sync.Map // key: file path, value: *ast.File
 }
 
-var packageCache = make(map[string]*loadPackagesResponse, 10240)
+// packageCache caches loadPackages results by directory path. It is a sync.Map rather
+// than a plain map guarded by a mutex because process parallelizes processDir across
+// directories, and directories are looked up far more often than they are populated.
+var packageCache sync.Map // key: dirPath string, value: *loadPackagesResponse
 
 func init() {
 	var err error
@@ -4,16 +4,15 @@ import (
 	"bytes"
 	"fmt"
 	"go/ast"
-	"go/parser"
-	"go/token"
-	"go/types"
 	"io/fs"
 	"os"
 	"path/filepath"
+	"runtime"
 	"strings"
 	"sync"
 	"text/template"
 
+	"golang.org/x/sync/errgroup"
 	"golang.org/x/tools/go/packages"
 	"golang.org/x/tools/imports"
 )
@@ -32,26 +31,181 @@ func Process(opts ...FuncOption) error {
 
 // process processes all Go files in a directory, either recursively or non-recursively
 func process(o *Options) error {
-	if o.Recursive {
-		// Walk the directory recursively
-		return filepath.WalkDir(o.Dir, func(path string, d fs.DirEntry, err error) error {
+	cfg, err := resolveConfig(o)
+	if err != nil {
+		return err
+	}
+
+	dirs, err := resolveDirs(o, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Validate once against the union of packages across every directory, rather than
+	// per-directory: a single config can reference structs/types scattered across a
+	// monorepo, and per-dir validation would reject references to anything not defined
+	// in that particular directory.
+	if cfg != nil {
+		if err := validateConfig(cfg, dirs); err != nil {
+			return err
+		}
+	}
+
+	w := writeOptions{Stdout: o.Stdout, Check: o.Check}
+
+	if len(dirs) == 1 {
+		if err := processDir(dirs[0], o.Mode, o.Concurrent, cfg, w); err != nil {
+			return fmt.Errorf("error processing directory %s: %w", dirs[0], err)
+		}
+		return nil
+	}
+
+	// Process every directory concurrently: recursive mode and config-driven package
+	// selection previously ran processDir serially one directory at a time.
+	g := new(errgroup.Group)
+	g.SetLimit(runtime.GOMAXPROCS(0))
+	for _, dir := range dirs {
+		dir := dir
+		g.Go(func() error {
+			return processDir(dir, o.Mode, o.Concurrent, cfg, w)
+		})
+	}
+	return g.Wait()
+}
+
+// resolveDirs returns the directories process should run against. A config with a
+// non-empty Packages list takes precedence, expanding its globs relative to o.Dir;
+// otherwise it's o.Dir alone, or every directory under it when o.Recursive is set.
+func resolveDirs(o *Options, cfg *Config) ([]string, error) {
+	if cfg != nil && len(cfg.Packages) > 0 {
+		return expandConfigPackages(o.Dir, cfg.Packages)
+	}
+
+	if !o.Recursive {
+		return []string{o.Dir}, nil
+	}
+
+	var dirs []string
+	if err := filepath.WalkDir(o.Dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			dirs = append(dirs, path)
+		}
+		return nil
+	}); err != nil {
+		return nil, fmt.Errorf("error walking directory %s: %w", o.Dir, err)
+	}
+	return dirs, nil
+}
+
+// expandConfigPackages resolves cfg.Packages-style patterns (each joined against
+// baseDir) to their containing directories, deduplicated and in the order first
+// matched. A pattern is a plain filepath.Glob pattern, except that "..." anywhere in
+// it is treated as in `go build`-style package patterns: the part of the path before
+// it is walked recursively, matching that directory and every directory beneath it.
+func expandConfigPackages(baseDir string, globs []string) ([]string, error) {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, pattern := range globs {
+		matches, err := expandConfigPackagePattern(baseDir, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("error expanding config package pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("config package pattern %q matched no files or directories", pattern)
+		}
+		for _, match := range matches {
+			fi, err := os.Stat(match)
 			if err != nil {
-				return err
+				return nil, fmt.Errorf("error statting config package path %s: %w", match, err)
 			}
-			if !d.IsDir() {
-				return nil
+			dir := match
+			if !fi.IsDir() {
+				dir = filepath.Dir(match)
+			}
+			if !seen[dir] {
+				seen[dir] = true
+				dirs = append(dirs, dir)
 			}
-			return processDir(path, o.Mode)
-		})
-	} else {
-		if err := processDir(o.Dir, o.Mode); err != nil {
-			return fmt.Errorf("error processing directory %s: %w", o.Dir, err)
 		}
 	}
+	return dirs, nil
+}
+
+// expandConfigPackagePattern resolves a single config package pattern, recognizing the
+// `go build ./...`-style "..." wildcard in addition to filepath.Glob patterns.
+func expandConfigPackagePattern(baseDir, pattern string) ([]string, error) {
+	if !strings.Contains(pattern, "...") {
+		return filepath.Glob(filepath.Join(baseDir, pattern))
+	}
+
+	prefix := strings.TrimSuffix(strings.SplitN(pattern, "...", 2)[0], "/")
+	root := filepath.Join(baseDir, prefix)
+
+	var dirs []string
+	if err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			dirs = append(dirs, path)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return dirs, nil
+}
+
+// validateConfig validates cfg once against the union of packages loaded across every
+// directory in dirs, via the directory-keyed cache loadPackages already maintains.
+func validateConfig(cfg *Config, dirs []string) error {
+	var allPkgs []*packages.Package
+	for _, dir := range dirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("error getting absolute path for %s: %w", dir, err)
+		}
+		resp, err := loadPackages(absDir)
+		if err != nil {
+			return fmt.Errorf("error loading packages: %w", err)
+		}
+		allPkgs = append(allPkgs, resp.packages...)
+	}
+	if err := cfg.Validate(allPkgs); err != nil {
+		return fmt.Errorf("error validating config: %w", err)
+	}
 	return nil
 }
 
-func processDir(dirPath string, mode ModeEnum) error {
+// writeOptions controls how processFile emits generated output.
+type writeOptions struct {
+	Stdout bool // write to stdout as one concatenated stream instead of *_accessor_gen.go files
+	Check  bool // fail if generated output would differ from what's on disk, without writing
+}
+
+// resolveConfig returns the config to use for this run. An explicit o.Config takes
+// precedence; otherwise the file at o.ConfigPath (or the default ".accessorgen.yaml"
+// in o.Dir) is loaded if present. It is not an error for no config file to exist.
+func resolveConfig(o *Options) (*Config, error) {
+	if o.Config != nil {
+		return o.Config, nil
+	}
+
+	path := o.ConfigPath
+	if path == "" {
+		path = filepath.Join(o.Dir, defaultConfigFile)
+	}
+	cfg, err := LoadConfig(path)
+	if err != nil {
+		return nil, fmt.Errorf("error loading config: %w", err)
+	}
+	return cfg, nil
+}
+
+func processDir(dirPath string, mode ModeEnum, concurrent bool, cfg *Config, w writeOptions) error {
 	dirPath, err := filepath.Abs(dirPath)
 	if err != nil {
 		return fmt.Errorf("error getting absolute path for %s: %w", dirPath, err)
@@ -70,7 +224,7 @@ func processDir(dirPath string, mode ModeEnum) error {
 				return fmt.Errorf("error loading ast file for %s", filePath)
 			}
 			astFile := astFileInterface.(*ast.File)
-			if err := processFile(pkgs, astFile, filePath, mode); err != nil {
+			if err := processFile(pkgs, astFile, filePath, mode, concurrent, cfg, w); err != nil {
 				return fmt.Errorf("error processing file %s: %w", filePath, err)
 			}
 		}
@@ -79,72 +233,20 @@ func processDir(dirPath string, mode ModeEnum) error {
 	return nil
 }
 
-func processFile(pkgs []*packages.Package, node *ast.File, filePath string, mode ModeEnum) error {
+func processFile(pkgs []*packages.Package, node *ast.File, filePath string, mode ModeEnum, concurrent bool, cfg *Config, w writeOptions) error {
 	if ignoreFilePath(filepath.Base(filePath)) {
 		return nil
 	}
 
-	dirPath := filepath.Dir(filePath)
-	imports := collectImports(node)
-
-	// Collect struct information
-	var structs []StructInfo
-	fieldCnt := 0
-	for _, decl := range node.Decls {
-		genDecl, ok := decl.(*ast.GenDecl)
-		if !ok || genDecl.Tok != token.TYPE {
-			continue
-		}
-
-		for _, spec := range genDecl.Specs {
-			typeSpec, ok := spec.(*ast.TypeSpec)
-			if !ok {
-				continue
-			}
-
-			structType, ok := typeSpec.Type.(*ast.StructType)
-			if !ok {
-				continue
-			}
-
-			var fields []StructField
-			for _, field := range structType.Fields.List {
-				fieldType := exprToString(field.Type)
-				deferrencedFieldType := ""
-				primitivePointer := isPrimitivePointer(field.Type, dirPath)
-				if primitivePointer {
-					deferrencedFieldType = fieldType[1:]
-				}
-				for _, fieldName := range field.Names {
-					fieldCnt += 1
-					fields = append(fields, StructField{
-						Name:                 fieldName.Name,
-						Type:                 fieldType,
-						DeferrencedFieldType: deferrencedFieldType,
-						PrimitivePointer:     primitivePointer,
-					})
-				}
-			}
-
-			structs = append(structs, StructInfo{
-				StructName: typeSpec.Name.Name,
-				Fields:     fields,
-			})
-		}
+	data, err := collectTmplData(node, filePath, mode, concurrent, pkgs, cfg)
+	if err != nil {
+		return err
 	}
-
 	// If no field found, skip file
-	if fieldCnt == 0 {
+	if data == nil {
 		return nil
 	}
 
-	// Generate the output file content
-	data := FileData{
-		PackageName: node.Name.Name,
-		Imports:     imports,
-		Structs:     structs,
-		Mode:        mode,
-	}
 	var output bytes.Buffer
 	tmpl := template.Must(
 		template.New("accessor").
@@ -161,12 +263,29 @@ func processFile(pkgs []*packages.Package, node *ast.File, filePath string, mode
 		return fmt.Errorf("error formatting file %v: %v", filePath, err)
 	}
 
-	// Write the formatted output to a new Go file
-	outputFilePath := strings.TrimSuffix(filePath, ".go") + "_accessor_gen.go"
-	if strings.HasSuffix(filePath, "_gen.go") {
-		outputFilePath = strings.TrimSuffix(filePath, "_gen.go") + "_accessor_gen.go"
+	outputFilePath, err := outputFilePathFor(filePath, cfg)
+	if err != nil {
+		return err
 	}
-	if err := os.WriteFile(outputFilePath, formattedSource, 0o644); err != nil {
+
+	if w.Check {
+		return checkUpToDate(outputFilePath, formattedSource)
+	}
+
+	if w.Stdout {
+		writeToStdout(outputFilePath, formattedSource)
+		return nil
+	}
+
+	// Preserve the existing file's permissions (important on Windows and for repos
+	// that check file modes into version control); only new files get the default.
+	perm := os.FileMode(0o644)
+	if fi, err := os.Stat(outputFilePath); err == nil {
+		perm = fi.Mode() & os.ModePerm
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("error statting file %v: %v", outputFilePath, err)
+	}
+	if err := os.WriteFile(outputFilePath, formattedSource, perm); err != nil {
 		return fmt.Errorf("error writing file %v: %v", outputFilePath, err)
 	}
 
@@ -174,64 +293,70 @@ func processFile(pkgs []*packages.Package, node *ast.File, filePath string, mode
 	return nil
 }
 
-// ignoreFilePath returns true if the directory entry should be ignored.
-func ignoreFilePath(path string) bool {
-	return !strings.HasSuffix(path, ".go") ||
-		strings.HasSuffix(path, "_accessor_gen.go") ||
-		strings.HasSuffix(path, "_test.go")
-}
-
-// isPrimitivePointer checks if a field is a pointer to a primitive type and returns the type name.
-func isPrimitivePointer(fieldType ast.Expr, dirPath string) bool {
-	starExpr, ok := fieldType.(*ast.StarExpr)
-	if !ok {
-		return false
-	}
-	resp, _ := loadPackages(dirPath) // second time call shall read from cache without error
-	pkgs := resp.packages
-
-	for _, pkg := range pkgs {
-		typ := pkg.TypesInfo.TypeOf(starExpr.X)
-		if typ == nil {
-			continue
-		}
-
-		if _, ok := typ.Underlying().(*types.Basic); ok {
-			return true
+// checkUpToDate reports an error if outputFilePath doesn't already contain
+// formattedSource, without writing anything. Used by --check to enforce in CI that
+// committed generated files are up to date.
+func checkUpToDate(outputFilePath string, formattedSource []byte) error {
+	existing, err := os.ReadFile(outputFilePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%s would be generated but does not exist", outputFilePath)
 		}
+		return fmt.Errorf("error reading file %v: %v", outputFilePath, err)
+	}
+	if !bytes.Equal(existing, formattedSource) {
+		return fmt.Errorf("%s is out of date", outputFilePath)
 	}
+	return nil
+}
 
-	return false
+// stdoutMu serializes --stdout writes so concurrent processDir calls don't interleave
+// banners and file content.
+var stdoutMu sync.Mutex
+
+// writeToStdout writes formattedSource to stdout as part of one concatenated stream,
+// preceded by a "// file: ..." banner identifying outputFilePath.
+func writeToStdout(outputFilePath string, formattedSource []byte) {
+	stdoutMu.Lock()
+	defer stdoutMu.Unlock()
+	fmt.Printf("// file: %s\n", outputFilePath)
+	os.Stdout.Write(formattedSource)
 }
 
-// loadPackages loads the package with the specific name at the specified directory path with cache.
-func loadPackages(dirPath string) (*loadPackagesResponse, error) {
-	if result, ok := packageCache[dirPath]; ok {
-		return result, nil
-	}
+// outputNameData is the data passed to cfg.Output when rendering it as a template.
+type outputNameData struct {
+	Dir  string // directory containing the source file
+	Base string // source file name without the .go extension or _gen suffix
+}
 
-	astFiles := &sync.Map{}
-	cfg := &packages.Config{
-		Mode: packages.LoadAllSyntax,
-		Dir:  dirPath,
-		ParseFile: func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
-			file, err := parser.ParseFile(fset, filename, src, parser.ParseComments)
-			astFiles.Store(filename, file)
-			return file, err
-		},
+// outputFilePathFor returns the path methodTemplate's output should be written to for
+// filePath. If cfg sets Output, it's rendered as a text/template against an
+// outputNameData; otherwise the default "<base>_accessor_gen.go" naming is used.
+func outputFilePathFor(filePath string, cfg *Config) (string, error) {
+	dir := filepath.Dir(filePath)
+	base := strings.TrimSuffix(filepath.Base(filePath), ".go")
+	base = strings.TrimSuffix(base, "_gen")
+
+	if cfg == nil || cfg.Output == "" {
+		return filepath.Join(dir, base+"_accessor_gen.go"), nil
 	}
-	pkgs, err := packages.Load(cfg)
+
+	tmpl, err := template.New("output").Parse(cfg.Output)
 	if err != nil {
-		return nil, fmt.Errorf("error loading package for %s: %w", dirPath, err)
+		return "", fmt.Errorf("error parsing config output template %q: %w", cfg.Output, err)
 	}
-	resp := &loadPackagesResponse{
-		packages: pkgs,
-		astFiles: astFiles,
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, outputNameData{Dir: dir, Base: base}); err != nil {
+		return "", fmt.Errorf("error executing config output template %q: %w", cfg.Output, err)
 	}
+	return filepath.Join(dir, buf.String()), nil
+}
 
-	packageCache[dirPath] = resp
-
-	return resp, nil
+// ignoreFilePath returns true if the directory entry should be ignored.
+func ignoreFilePath(path string) bool {
+	return !strings.HasSuffix(path, ".go") ||
+		strings.HasSuffix(path, "_accessor_gen.go") ||
+		strings.HasSuffix(path, "_test.go")
 }
 
 // goImportsAndFormat formats the Go code and fixes imports using the imports.Process function.
@@ -246,41 +371,3 @@ func goImportsAndFormat(source []byte, filename string) ([]byte, error) {
 	}
 	return imports.Process(filename, source, options)
 }
-
-// collectImports extracts all import statements from the parsed file.
-func collectImports(node *ast.File) (imports []string) {
-	for _, imp := range node.Imports {
-		str := imp.Path.Value
-		if imp.Name != nil {
-			str = imp.Name.Name + " " + str // import with alias
-		}
-		imports = append(imports, str)
-	}
-	return imports
-}
-
-// exprToString converts an expression (field type) to its string representation.
-func exprToString(expr ast.Expr) string {
-	switch t := expr.(type) {
-	case *ast.Ident:
-		return t.Name
-	case *ast.StarExpr:
-		return "*" + exprToString(t.X)
-	case *ast.SelectorExpr:
-		return exprToString(t.X) + "." + t.Sel.Name
-	case *ast.ArrayType:
-		return "[]" + exprToString(t.Elt)
-	case *ast.MapType:
-		return "map[" + exprToString(t.Key) + "]" + exprToString(t.Value)
-	case *ast.IndexExpr:
-		return exprToString(t.X) + "[" + exprToString(t.Index) + "]"
-	case *ast.IndexListExpr:
-		indices := make([]string, len(t.Indices))
-		for i, index := range t.Indices {
-			indices[i] = exprToString(index)
-		}
-		return exprToString(t.X) + "[" + strings.Join(indices, ", ") + "]"
-	default:
-		return ""
-	}
-}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCheckUpToDate(t *testing.T) {
+	dir := t.TempDir()
+
+	upToDatePath := filepath.Join(dir, "up_to_date.go")
+	if err := os.WriteFile(upToDatePath, []byte("package main\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	stalePath := filepath.Join(dir, "stale.go")
+	if err := os.WriteFile(stalePath, []byte("package old\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	missingPath := filepath.Join(dir, "missing.go")
+
+	tests := []struct {
+		name            string
+		outputFilePath  string
+		formattedSource []byte
+		wantErr         bool
+	}{
+		{
+			name:            "matches existing file",
+			outputFilePath:  upToDatePath,
+			formattedSource: []byte("package main\n"),
+		},
+		{
+			name:            "differs from existing file",
+			outputFilePath:  stalePath,
+			formattedSource: []byte("package main\n"),
+			wantErr:         true,
+		},
+		{
+			name:            "file does not exist",
+			outputFilePath:  missingPath,
+			formattedSource: []byte("package main\n"),
+			wantErr:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkUpToDate(tt.outputFilePath, tt.formattedSource)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("checkUpToDate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
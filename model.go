@@ -4,8 +4,13 @@ package main
 type StructField struct {
 	Name                 string
 	Type                 string
-	DeferrencedFieldType string // the type of the field after deferrencing, if type type is a pointer to a primitive
-	PrimitivePointer     bool   // true if the field is a pointer to a primitive type
+	DeferrencedFieldType string   // the type of the field after deferrencing, if type type is a pointer to a primitive
+	PrimitivePointer     bool     // true if the field is a pointer to a primitive type
+	MethodName           string   // name used for the generated accessor method; defaults to Name
+	GetterBody           string   // explicit getter body from a config Types override, if any
+	SetterBody           string   // explicit setter body from a config Types override, if any
+	Mode                 ModeEnum // per-field mode override from an `accessor:"..."` tag; zero value means use StructInfo.Mode
+	DeepCopyStmt         string   // Go statement(s) to copy this field in DeepCopyInto; empty means the blanket `*out = *in` already handles it
 }
 
 // StructInfo holds information about a struct and its fields
@@ -13,6 +18,9 @@ type StructInfo struct {
 	StructName    string
 	Fields        []StructField
 	TypeParamsStr string
+	Mode          ModeEnum // per-struct mode override from config; zero value means use FileData.Mode
+	LockField     string   // promoted field name of an embedded sync.Mutex/sync.RWMutex, set when Concurrent is enabled
+	LockRWCapable bool     // true if LockField is a sync.RWMutex (supports RLock/RUnlock); false means plain sync.Mutex
 }
 
 // FileData holds necessary data to generate the target file
@@ -21,4 +29,5 @@ type FileData struct {
 	Imports     []string
 	Structs     []StructInfo
 	Mode        ModeEnum
+	Concurrent  bool // true if accessors should be generated with RLock/RUnlock and Lock/Unlock guards
 }
@@ -16,16 +16,24 @@ func printUsage() {
 	fmt.Printf("Usage: %s [options]\n", os.Args[0])
 	fmt.Println("Options:")
 	fmt.Println("  --dir        Directory to process (default is current working directory)")
-	fmt.Println("  --mode       Mode to generate: 'getter', 'setter', or 'accessor' (default: accessor)")
+	fmt.Println("  --mode       Mode to generate: 'getter', 'setter', 'accessor', 'deepcopy', or 'builder' (default: accessor)")
 	fmt.Println("  --recursive  Recursively process directories (default: false)")
+	fmt.Println("  --config     Path to a YAML config file (default: .accessorgen.yaml if present)")
+	fmt.Println("  --concurrent Wrap generated accessors with RLock/RUnlock and Lock/Unlock (default: false)")
+	fmt.Println("  --stdout     Write generated content to stdout instead of *_accessor_gen.go files")
+	fmt.Println("  --check      Exit non-zero if generated output is out of date, without writing")
 	fmt.Println("  --version    Show version information")
 	fmt.Println("  --help       Show this help message")
 }
 
 func funcOptionsFromFlags() FuncOptions {
 	dirFlag := flag.String("dir", cwd, "directory to process")
-	modeFlag := flag.String("mode", ModeAccessor.String(), "getter, setter, accessor")
+	modeFlag := flag.String("mode", ModeAccessor.String(), "getter, setter, accessor, deepcopy, builder")
 	recursiveFlag := flag.Bool("recursive", false, "process directory recursively")
+	configFlag := flag.String("config", "", "path to a YAML config file (default: .accessorgen.yaml if present)")
+	concurrentFlag := flag.Bool("concurrent", false, "wrap generated accessors with RLock/RUnlock and Lock/Unlock")
+	stdoutFlag := flag.Bool("stdout", false, "write generated content to stdout instead of *_accessor_gen.go files")
+	checkFlag := flag.Bool("check", false, "exit non-zero if generated output is out of date, without writing")
 	versionFlag := flag.Bool("version", false, "display version information")
 
 	flag.Usage = printUsage
@@ -40,6 +48,10 @@ func funcOptionsFromFlags() FuncOptions {
 		Dir(*dirFlag),
 		Mode(*modeFlag),
 		Recursive(*recursiveFlag),
+		ConfigFile(*configFlag),
+		Concurrent(*concurrentFlag),
+		Stdout(*stdoutFlag),
+		Check(*checkFlag),
 	}
 }
 
@@ -61,12 +73,19 @@ const (
 	ModeGetter   ModeEnum = "getter"
 	ModeSetter   ModeEnum = "setter"
 	ModeAccessor ModeEnum = "accessor"
+	ModeDeepCopy ModeEnum = "deepcopy"
+	ModeBuilder  ModeEnum = "builder"
 )
 
 type Options struct {
-	Dir       string   // Default is the current working directory.
-	Mode      ModeEnum // Default is accessor.
-	Recursive bool     // Default is false.
+	Dir        string   // Default is the current working directory.
+	Mode       ModeEnum // Default is accessor.
+	Recursive  bool     // Default is false.
+	ConfigPath string   // Path to a YAML config file. Default is ".accessorgen.yaml" in Dir, if present.
+	Config     *Config  // Parsed config, if already loaded. Takes precedence over ConfigPath.
+	Concurrent bool     // Default is false. Wraps generated getters/setters with RLock/RUnlock and Lock/Unlock.
+	Stdout     bool     // Default is false. Writes generated content to stdout instead of *_accessor_gen.go files.
+	Check      bool     // Default is false. Fails if generated output would differ from what's on disk, without writing.
 }
 
 type FuncOptions []FuncOption
@@ -110,6 +129,21 @@ func Recursive(recursive bool) FuncOption {
 	}
 }
 
+// ConfigFile sets the path to a YAML config file to drive generation. An empty path
+// falls back to ".accessorgen.yaml" in Dir, if present.
+func ConfigFile(path string) FuncOption {
+	return func(o *Options) {
+		o.ConfigPath = path
+	}
+}
+
+// WithConfig sets an already-loaded config, taking precedence over ConfigPath.
+func WithConfig(cfg *Config) FuncOption {
+	return func(o *Options) {
+		o.Config = cfg
+	}
+}
+
 // EnableGetters enables generation of getters without overriding the previous mode.
 func EnableGetters() FuncOption {
 	return func(o *Options) {
@@ -157,3 +191,46 @@ func DisableSetters() FuncOption {
 		}
 	}
 }
+
+// EnableDeepCopy switches generation to emit DeepCopy/DeepCopyInto methods instead of
+// getters/setters.
+func EnableDeepCopy() FuncOption {
+	return func(o *Options) {
+		o.Mode = ModeDeepCopy
+	}
+}
+
+// EnableBuilder switches generation to emit chainable WithX builder methods instead of
+// getters/setters.
+func EnableBuilder() FuncOption {
+	return func(o *Options) {
+		o.Mode = ModeBuilder
+	}
+}
+
+// Concurrent sets the concurrent option. Default is false. When true, generated
+// getters/setters wrap field access in RLock/RUnlock and Lock/Unlock calls against
+// the struct's embedded sync.Mutex or sync.RWMutex.
+func Concurrent(concurrent bool) FuncOption {
+	return func(o *Options) {
+		o.Concurrent = concurrent
+	}
+}
+
+// Stdout sets the stdout option. Default is false. When true, generated content is
+// written to stdout as one concatenated stream with "// file: ..." banners instead of
+// being written to *_accessor_gen.go files.
+func Stdout(stdout bool) FuncOption {
+	return func(o *Options) {
+		o.Stdout = stdout
+	}
+}
+
+// Check sets the check option. Default is false. When true, process fails if the
+// generated output would differ from what's already on disk, without writing anything
+// — suitable for enforcing in CI that committed generated files are up to date.
+func Check(check bool) FuncOption {
+	return func(o *Options) {
+		o.Check = check
+	}
+}
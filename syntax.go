@@ -7,21 +7,30 @@ import (
 	"go/token"
 	"go/types"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"sync"
 
 	"golang.org/x/tools/go/packages"
 )
 
+// loadPackagesMode is the minimal set of go/packages.NeedXxx bits this package needs:
+// struct/field syntax (NeedSyntax) and enough type info (NeedTypes, NeedTypesInfo,
+// NeedDeps, NeedImports) to resolve isPrimitivePointer's pkg.TypesInfo.TypeOf lookup.
+// packages.LoadAllSyntax forces full type-checking of every transitive dependency,
+// which is far more than that lookup needs.
+const loadPackagesMode = packages.NeedName | packages.NeedFiles | packages.NeedSyntax |
+	packages.NeedTypes | packages.NeedTypesInfo | packages.NeedDeps | packages.NeedImports
+
 // loadPackages loads the package with the specific name at the specified directory path with cache.
 func loadPackages(dirPath string) (*loadPackagesResponse, error) {
-	if result, ok := packageCache[dirPath]; ok {
-		return result, nil
+	if result, ok := packageCache.Load(dirPath); ok {
+		return result.(*loadPackagesResponse), nil
 	}
 
 	astFiles := &sync.Map{}
 	cfg := &packages.Config{
-		Mode: packages.LoadAllSyntax,
+		Mode: loadPackagesMode,
 		Dir:  dirPath,
 		ParseFile: func(fset *token.FileSet, filename string, src []byte) (*ast.File, error) {
 			if file, ok := astFiles.Load(filename); ok {
@@ -42,14 +51,20 @@ func loadPackages(dirPath string) (*loadPackagesResponse, error) {
 		astFiles: astFiles,
 	}
 
-	packageCache[dirPath] = resp
+	actual, _ := packageCache.LoadOrStore(dirPath, resp)
 
-	return resp, nil
+	return actual.(*loadPackagesResponse), nil
 }
 
-func collectTmplData(node *ast.File, filePath string, mode ModeEnum) *FileData {
+// collectTmplData walks the declarations in node and builds the FileData consumed by
+// methodTemplate. When cfg is non-nil, per-struct overrides (mode, skip, rename) and
+// per-type getter/setter bodies from the config file are applied to the result. When
+// concurrent is true, each struct must already embed a sync.Mutex or sync.RWMutex;
+// pkgs supplies the go/types info used to find it.
+func collectTmplData(node *ast.File, filePath string, mode ModeEnum, concurrent bool, pkgs []*packages.Package, cfg *Config) (*FileData, error) {
 	dirPath := filepath.Dir(filePath)
 	imports := collectImports(node)
+	knownStructs := collectStructNames(node)
 
 	var structs []StructInfo
 	fieldCnt := 0
@@ -70,6 +85,15 @@ func collectTmplData(node *ast.File, filePath string, mode ModeEnum) *FileData {
 				continue
 			}
 
+			var override StructOverride
+			if cfg != nil {
+				override = cfg.Structs[typeSpec.Name.Name]
+			}
+			skip := make(map[string]bool, len(override.Skip))
+			for _, name := range override.Skip {
+				skip[name] = true
+			}
+
 			var fields []StructField
 			for _, field := range structType.Fields.List {
 				fieldType := exprToString(field.Type)
@@ -78,13 +102,45 @@ func collectTmplData(node *ast.File, filePath string, mode ModeEnum) *FileData {
 				if primitivePointer {
 					deferrencedFieldType = fieldType[1:]
 				}
+
+				var typeOverride TypeOverride
+				if cfg != nil {
+					typeOverride = cfg.Types[fieldType]
+				}
+
+				directive := parseAccessorTag(field)
+				if directive.ForceDeref && strings.HasPrefix(fieldType, "*") {
+					primitivePointer = true
+					deferrencedFieldType = fieldType[1:]
+				}
+
 				for _, fieldName := range field.Names {
+					if skip[fieldName.Name] || directive.Skip {
+						continue
+					}
 					fieldCnt += 1
+					methodName := fieldName.Name
+					if renamed, ok := override.Rename[fieldName.Name]; ok {
+						methodName = renamed
+					}
+					if directive.MethodName != "" {
+						methodName = directive.MethodName
+					}
+					fieldMode := directive.Mode
+					deepCopyStmt := ""
+					if mode == ModeDeepCopy {
+						deepCopyStmt = buildDeepCopyStmt(fieldName.Name, field.Type, fieldType, knownStructs, directive.ShallowInterface)
+					}
 					fields = append(fields, StructField{
 						Name:                 fieldName.Name,
 						Type:                 fieldType,
 						DeferrencedFieldType: deferrencedFieldType,
 						PrimitivePointer:     primitivePointer,
+						MethodName:           methodName,
+						GetterBody:           typeOverride.Getter,
+						SetterBody:           typeOverride.Setter,
+						Mode:                 fieldMode,
+						DeepCopyStmt:         deepCopyStmt,
 					})
 				}
 			}
@@ -103,17 +159,32 @@ func collectTmplData(node *ast.File, filePath string, mode ModeEnum) *FileData {
 				typeParamsStr = "[" + strings.Join(typeParams, ", ") + "]"
 			}
 
-			structs = append(structs, StructInfo{
+			structInfo := StructInfo{
 				StructName:    typeSpec.Name.Name,
 				Fields:        fields,
 				TypeParamsStr: typeParamsStr,
-			})
+				Mode:          ModeEnum(override.Mode),
+			}
+
+			if concurrent {
+				lock, found := findLockEmbed(pkgs, typeSpec.Name.Name)
+				if !found {
+					return nil, fmt.Errorf(
+						"struct %s must embed sync.Mutex or sync.RWMutex to generate concurrent accessors",
+						typeSpec.Name.Name,
+					)
+				}
+				structInfo.LockField = lock.FieldName
+				structInfo.LockRWCapable = lock.RWCapable
+			}
+
+			structs = append(structs, structInfo)
 		}
 	}
 
 	// If no field found, skip file
 	if fieldCnt == 0 {
-		return nil
+		return nil, nil
 	}
 
 	// Generate the output file content
@@ -122,7 +193,8 @@ func collectTmplData(node *ast.File, filePath string, mode ModeEnum) *FileData {
 		Imports:     imports,
 		Structs:     structs,
 		Mode:        mode,
-	}
+		Concurrent:  concurrent,
+	}, nil
 }
 
 // collectImports extracts all import statements from the parsed file.
@@ -163,6 +235,53 @@ func exprToString(expr ast.Expr) string {
 	}
 }
 
+// accessorDirective is the parsed form of a field's `accessor:"..."` struct tag.
+type accessorDirective struct {
+	Skip             bool     // "-": omit the field from generation entirely
+	Mode             ModeEnum // "getter"/"setter"/"readonly": restrict generation regardless of the file/struct mode
+	MethodName       string   // "name=Foo": rename the generated method
+	ForceDeref       bool     // "deref": emit pointer-dereferencing accessors even for non-primitive pointers
+	ShallowInterface bool     // "deepcopy=shallow": opt an interface field into a shallow DeepCopy assignment
+}
+
+// parseAccessorTag extracts the `accessor:"..."` directive from field's struct tag, if
+// any. The tag value is a comma-separated list of: "-" (skip), "getter", "setter",
+// "readonly" (getter only), "deref" (force pointer dereference), "deepcopy=shallow"
+// (opt an interface field into a shallow DeepCopy assignment), and "name=NewName".
+func parseAccessorTag(field *ast.Field) accessorDirective {
+	var d accessorDirective
+	if field.Tag == nil {
+		return d
+	}
+
+	raw, ok := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Lookup("accessor")
+	if !ok {
+		return d
+	}
+
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		switch {
+		case part == "-":
+			d.Skip = true
+		case part == "getter":
+			d.Mode = ModeGetter
+		case part == "setter":
+			d.Mode = ModeSetter
+		case part == "readonly":
+			d.Mode = ModeGetter
+		case part == "deref":
+			d.ForceDeref = true
+		case part == "deepcopy=shallow":
+			d.ShallowInterface = true
+		case strings.HasPrefix(part, "name="):
+			d.MethodName = strings.TrimPrefix(part, "name=")
+		}
+	}
+
+	return d
+}
+
 // isPrimitivePointer checks if a field is a pointer to a primitive type and returns the type name.
 func isPrimitivePointer(fieldType ast.Expr, dirPath string) bool {
 	starExpr, ok := fieldType.(*ast.StarExpr)
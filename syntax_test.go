@@ -0,0 +1,79 @@
+package main
+
+import (
+	"go/ast"
+	"reflect"
+	"testing"
+)
+
+func TestParseAccessorTag(t *testing.T) {
+	tests := []struct {
+		name string
+		tag  string
+		want accessorDirective
+	}{
+		{
+			name: "no tag",
+			want: accessorDirective{},
+		},
+		{
+			name: "no accessor key",
+			tag:  `json:"foo"`,
+			want: accessorDirective{},
+		},
+		{
+			name: "skip",
+			tag:  `accessor:"-"`,
+			want: accessorDirective{Skip: true},
+		},
+		{
+			name: "getter only",
+			tag:  `accessor:"getter"`,
+			want: accessorDirective{Mode: ModeGetter},
+		},
+		{
+			name: "setter only",
+			tag:  `accessor:"setter"`,
+			want: accessorDirective{Mode: ModeSetter},
+		},
+		{
+			name: "readonly is an alias for getter",
+			tag:  `accessor:"readonly"`,
+			want: accessorDirective{Mode: ModeGetter},
+		},
+		{
+			name: "force dereference",
+			tag:  `accessor:"deref"`,
+			want: accessorDirective{ForceDeref: true},
+		},
+		{
+			name: "shallow deepcopy",
+			tag:  `accessor:"deepcopy=shallow"`,
+			want: accessorDirective{ShallowInterface: true},
+		},
+		{
+			name: "rename",
+			tag:  `accessor:"name=Bar"`,
+			want: accessorDirective{MethodName: "Bar"},
+		},
+		{
+			name: "combined directives with whitespace",
+			tag:  `accessor:"getter, deref, name=Bar"`,
+			want: accessorDirective{Mode: ModeGetter, ForceDeref: true, MethodName: "Bar"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			field := &ast.Field{Names: []*ast.Ident{ast.NewIdent("F")}}
+			if tt.tag != "" {
+				field.Tag = &ast.BasicLit{Kind: 0, Value: "`" + tt.tag + "`"}
+			}
+
+			got := parseAccessorTag(field)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("parseAccessorTag() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}